@@ -4,6 +4,37 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// TacoOrderFinalizer is placed on a TacoOrder by the controller the first time it
+// reconciles the order, before any provider call, so the controller gets a chance to
+// cancel or confirm an in-flight provider order before the object is removed from the
+// API server. The admission webhooks do not add it.
+const TacoOrderFinalizer = "tacoorder.taco.io/finalizer"
+
+// DefaultProvider is the delivery provider a TacoOrder is defaulted to by the mutating
+// webhook when Spec.Provider is left unset, so the choice is persisted onto the object
+// rather than only applied in-memory by the controller.
+const DefaultProvider = "mealme"
+
+// Condition types reported in TacoOrderStatus.Conditions.
+const (
+	// ConditionPaymentAccepted indicates the payment method was charged by the delivery provider.
+	ConditionPaymentAccepted = "PaymentAccepted"
+	// ConditionRestaurantFound indicates a restaurant matching the order's variety was located.
+	ConditionRestaurantFound = "RestaurantFound"
+	// ConditionOrderPlaced indicates the order was accepted by the delivery provider.
+	ConditionOrderPlaced = "OrderPlaced"
+	// ConditionDelivered indicates the order has been delivered.
+	ConditionDelivered = "Delivered"
+	// ConditionDeliveryTerminal indicates the delivery provider has reported a terminal
+	// state for the order (delivered, failed, or refunded) and it should no longer be
+	// routed to delivery tracking. Unlike ConditionDelivered, which is not True both while
+	// the order is "not yet delivered" (unset) and once it was "delivered unsuccessfully"
+	// (False), this condition is only ever True
+	// once a terminal state is reached, so reconciles triggered for unrelated reasons (a
+	// metadata edit, a periodic resync) can distinguish the two.
+	ConditionDeliveryTerminal = "DeliveryTerminal"
+)
+
 // TacoOrderSpec defines the desired state of TacoOrder.
 type TacoOrderSpec struct {
 	// Quantity is the number of tacos to order.
@@ -19,12 +50,41 @@ type TacoOrderSpec struct {
 
 	// AddressSecretName is the name of the Kubernetes Secret containing delivery address data.
 	AddressSecretName string `json:"addressSecretName"`
+
+	// Provider selects which registered delivery provider to use for this order (e.g.
+	// "mealme", "doordash", "ubereats"). The mutating webhook defaults and persists this
+	// to DefaultProvider on create if left unset.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// ProviderCredentialsSecretName is the name of the Kubernetes Secret containing the
+	// selected provider's API credentials. Required even when Provider is left to default
+	// to "mealme", since there's no well-known Secret name the controller could guess.
+	ProviderCredentialsSecretName string `json:"providerCredentialsSecretName"`
 }
 
 // TacoOrderStatus defines the observed state of TacoOrder.
 type TacoOrderStatus struct {
-	// Phase indicates the current state of the order (e.g., "Created", "Paid", "Delivered", "Canceled").
-	Phase string `json:"phase,omitempty"`
+	// Conditions represent the latest available observations of the order's state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// ExternalOrderID is the order identifier assigned by the delivery provider once
+	// the order has been placed. Once set, a reconcile will not place the order again.
+	// +optional
+	ExternalOrderID string `json:"externalOrderID,omitempty"`
+
+	// RestaurantID is the identifier of the restaurant selected to fulfill the order.
+	// +optional
+	RestaurantID string `json:"restaurantID,omitempty"`
+
+	// ObservedGeneration is the most recent Spec generation the controller has acted on.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
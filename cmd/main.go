@@ -1,25 +1,44 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"os"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	// Import your generated TacoOrder types. Adjust the module path as needed.
-	tacoV1alpha1 "github.com/cwarje/taco-operator/pkg/apis/tacoorder/v1alpha1"
+	tacoV1alpha1 "github.com/cwarje/taco-operator/api/v1alpha1"
+	"github.com/cwarje/taco-operator/pkg/bootstrap"
+	"github.com/cwarje/taco-operator/pkg/delivery"
+	tacowebhook "github.com/cwarje/taco-operator/pkg/webhook"
+
+	// Register the built-in delivery providers.
+	_ "github.com/cwarje/taco-operator/pkg/delivery/doordash"
+	_ "github.com/cwarje/taco-operator/pkg/delivery/mealme"
+	_ "github.com/cwarje/taco-operator/pkg/delivery/ubereats"
 )
 
+// tacoOrderCRDName is the CustomResourceDefinition the operator depends on.
+const tacoOrderCRDName = "tacoorders.taco.io"
+
 // scheme is a runtime.Scheme that holds all resource types we use.
 var scheme = runtime.NewScheme()
 
@@ -27,21 +46,73 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	// Register TacoOrder CRD scheme.
 	_ = tacoV1alpha1.AddToScheme(scheme)
+	// Needed by the CRD-readiness bootstrap gate.
+	_ = apiextensionsv1.AddToScheme(scheme)
 }
 
-// MealMe API constants
-const (
-	mealMeRestaurantSearchEndpoint = "https://api.mealme.ai/v1/restaurants/search"
-	mealMeOrderEndpoint            = "https://api.mealme.ai/v1/orders"
-	maxDeliveryDistance            = 5.0 // in miles
-)
+// providerCacheKey identifies a resolved delivery.DeliveryProvider by everything that
+// determines which credentials it was built from. Keying on Provider name alone would let
+// two TacoOrders in the same namespace that select the same provider but different
+// ProviderCredentialsSecretName share one tenant's cached credentials.
+type providerCacheKey struct {
+	namespace  string
+	name       string
+	secretName string
+}
 
-// TacoOrderReconciler watches for TacoOrder resources.
+// TacoOrderReconciler watches for TacoOrder resources. Build one with
+// NewTacoOrderReconciler rather than constructing it directly.
 type TacoOrderReconciler struct {
 	client.Client
+
+	// providers caches resolved delivery.DeliveryProvider instances by providerCacheKey,
+	// so orders that select the same provider but reference different credentials Secrets
+	// never share a cached client.
+	providers map[providerCacheKey]delivery.DeliveryProvider
+
+	// presetProviders holds providers pre-registered via WithDeliveryProvider, keyed by
+	// provider name. providerFor checks this first and, on a hit, bypasses the
+	// Secret-backed lookup entirely; it exists so tests can inject a fake provider without
+	// a client to fetch Secrets from.
+	presetProviders map[string]delivery.DeliveryProvider
+
+	httpClient          *http.Client
+	clock               Clock
+	maxDeliveryDistance float64
+	requeueBackoff      BackoffConfig
+	recorder            record.EventRecorder
+	finalizerName       string
+	pollInterval        time.Duration
+}
+
+// DefaultPollInterval is how often the reconciler checks a placed order's delivery
+// status until it reaches a terminal state.
+const DefaultPollInterval = 30 * time.Second
+
+// NewTacoOrderReconciler builds a TacoOrderReconciler with sensible defaults, applying
+// opts in order. It returns an error if any option rejects its input.
+func NewTacoOrderReconciler(c client.Client, opts ...Option) (*TacoOrderReconciler, error) {
+	r := &TacoOrderReconciler{
+		Client:              c,
+		httpClient:          http.DefaultClient,
+		clock:               realClock{},
+		maxDeliveryDistance: delivery.DefaultMaxDeliveryDistance,
+		requeueBackoff:      DefaultBackoffConfig,
+		finalizerName:       tacoV1alpha1.TacoOrderFinalizer,
+		pollInterval:        DefaultPollInterval,
+	}
+
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
 }
 
-// Reconcile fetches a TacoOrder object, processes it using MealMe's API, and updates its status.
+// Reconcile fetches a TacoOrder object, processes it using its selected delivery
+// provider, and updates its status.
 func (r *TacoOrderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var order tacoV1alpha1.TacoOrder
 	if err := r.Get(ctx, req.NamespacedName, &order); err != nil {
@@ -50,194 +121,343 @@ func (r *TacoOrderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	ctrl.LoggerFrom(ctx).Info("Reconciling TacoOrder", "order", order.Name)
 
+	if !order.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalizeTacoOrder(ctx, &order)
+	}
+
+	if !controllerutil.ContainsFinalizer(&order, r.finalizerName) {
+		controllerutil.AddFinalizer(&order, r.finalizerName)
+		if err := r.Update(ctx, &order); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	// Once the order has been placed, hand off to delivery tracking: polling for a
+	// terminal state is a different job than placing the order, and stamping Delivered
+	// right after OrderPlaced (as this used to) was fiction, since the food hadn't moved
+	// yet.
+	if apimeta.IsStatusConditionTrue(order.Status.Conditions, tacoV1alpha1.ConditionOrderPlaced) &&
+		!apimeta.IsStatusConditionTrue(order.Status.Conditions, tacoV1alpha1.ConditionDeliveryTerminal) {
+		return r.trackDelivery(ctx, &order)
+	}
+
 	// Execute the business logic for the TacoOrder.
-	if err := ReconcileTacoOrder(ctx, r.Client, &order); err != nil {
+	if err := r.ReconcileTacoOrder(ctx, &order); err != nil {
 		ctrl.LoggerFrom(ctx).Error(err, "Failed to reconcile TacoOrder", "order", order.Name)
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
-}
-
-// SetupWithManager registers this reconciler with the manager.
-func (r *TacoOrderReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&tacoV1alpha1.TacoOrder{}).
-		Complete(r)
+	return ctrl.Result{RequeueAfter: r.pollInterval}, nil
 }
 
-// ReconcileTacoOrder contains the main business logic for processing a TacoOrder.
-// It fetches Secrets, searches for a nearby taco restaurant, places the order via MealMe,
-// and updates the order status.
-func ReconcileTacoOrder(ctx context.Context, c client.Client, order *tacoV1alpha1.TacoOrder) error {
-	// 1. Fetch payment and address Secrets.
-	paymentSecret, err := getSecret(ctx, c, order.Namespace, order.Spec.PaymentSecretName)
+// trackDelivery polls the delivery provider for a previously placed order's current
+// state, maps it onto conditions and Kubernetes Events, and keeps requeuing with jitter
+// until a terminal state is reached.
+func (r *TacoOrderReconciler) trackDelivery(ctx context.Context, order *tacoV1alpha1.TacoOrder) (ctrl.Result, error) {
+	provider, err := r.providerFor(ctx, order)
 	if err != nil {
-		return fmt.Errorf("failed to fetch payment secret: %w", err)
+		return ctrl.Result{}, err
 	}
-	addressSecret, err := getSecret(ctx, c, order.Namespace, order.Spec.AddressSecretName)
+
+	state, err := provider.GetOrderStatus(ctx, order.Status.ExternalOrderID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch address secret: %w", err)
+		return ctrl.Result{}, fmt.Errorf("failed to fetch delivery status: %w", err)
 	}
 
-	// 2. Retrieve sensitive data.
-	cardNumber := string(paymentSecret.Data["cardNumber"])
-	cardExpiry := string(paymentSecret.Data["cardExpiry"])
-	cardCvv := string(paymentSecret.Data["cardCvv"])
+	key := client.ObjectKeyFromObject(order)
 
-	street := string(addressSecret.Data["street"])
-	city := string(addressSecret.Data["city"])
-	state := string(addressSecret.Data["state"])
-	zip := string(addressSecret.Data["zip"])
-	fullAddress := fmt.Sprintf("%s, %s, %s %s", street, city, state, zip)
+	switch state.Status {
+	case "preparing":
+		r.recordEvent(order, v1.EventTypeNormal, "DeliveryPreparing", "order is being prepared")
+		return ctrl.Result{RequeueAfter: jitter(r.pollInterval)}, nil
 
-	// 3. Update order status to "Created".
-	if err := updateOrderPhase(ctx, c, order, "Created"); err != nil {
-		return err
-	}
+	case "en_route":
+		r.recordEvent(order, v1.EventTypeNormal, "DeliveryEnRoute", "order is en route")
+		return ctrl.Result{RequeueAfter: jitter(r.pollInterval)}, nil
 
-	// 4. Search for a nearby taco restaurant using MealMe API.
-	restaurant, err := findNearestTacoRestaurant(fullAddress, order.Spec.Variety)
-	if err != nil {
-		updateOrderPhase(ctx, c, order, "Canceled")
-		return fmt.Errorf("restaurant search failed: %w", err)
-	}
+	case "delivered":
+		if _, err := UpdateStatusWithRetry(ctx, r.Client, key, r.requeueBackoff,
+			func(o *tacoV1alpha1.TacoOrder) bool {
+				return apimeta.IsStatusConditionTrue(o.Status.Conditions, tacoV1alpha1.ConditionDeliveryTerminal)
+			},
+			func(o *tacoV1alpha1.TacoOrder) error {
+				r.setCondition(o, tacoV1alpha1.ConditionDelivered, metav1.ConditionTrue, "Delivered", "provider reported the order delivered")
+				r.setCondition(o, tacoV1alpha1.ConditionDeliveryTerminal, metav1.ConditionTrue, "Delivered", "provider reported the order delivered")
+				return nil
+			},
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recordEvent(order, v1.EventTypeNormal, "DeliveryDelivered", "order was delivered")
+		return ctrl.Result{}, nil
 
-	// 5. Place the taco order via MealMe API.
-	orderID, err := placeTacoOrder(restaurant.ID, order.Spec.Quantity, order.Spec.Variety, fullAddress,
-		cardNumber, cardExpiry, cardCvv)
-	if err != nil {
-		updateOrderPhase(ctx, c, order, "Canceled")
-		return fmt.Errorf("order placement failed: %w", err)
-	}
+	case "failed":
+		if _, err := UpdateStatusWithRetry(ctx, r.Client, key, r.requeueBackoff,
+			func(o *tacoV1alpha1.TacoOrder) bool {
+				return apimeta.IsStatusConditionTrue(o.Status.Conditions, tacoV1alpha1.ConditionDeliveryTerminal)
+			},
+			func(o *tacoV1alpha1.TacoOrder) error {
+				r.setCondition(o, tacoV1alpha1.ConditionDelivered, metav1.ConditionFalse, "Failed", "provider reported the order failed")
+				r.setCondition(o, tacoV1alpha1.ConditionDeliveryTerminal, metav1.ConditionTrue, "Failed", "provider reported the order failed")
+				return nil
+			},
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recordEvent(order, v1.EventTypeWarning, "DeliveryFailed", "provider reported the order failed")
+		return ctrl.Result{}, nil
 
-	// 6. Update status to "Paid" (order accepted and charged).
-	if err := updateOrderPhase(ctx, c, order, "Paid"); err != nil {
-		return err
-	}
+	case "refunded":
+		if _, err := UpdateStatusWithRetry(ctx, r.Client, key, r.requeueBackoff,
+			func(o *tacoV1alpha1.TacoOrder) bool {
+				return apimeta.IsStatusConditionTrue(o.Status.Conditions, tacoV1alpha1.ConditionDeliveryTerminal)
+			},
+			func(o *tacoV1alpha1.TacoOrder) error {
+				r.setCondition(o, tacoV1alpha1.ConditionDelivered, metav1.ConditionFalse, "Refunded", "provider refunded the order")
+				r.setCondition(o, tacoV1alpha1.ConditionDeliveryTerminal, metav1.ConditionTrue, "Refunded", "provider refunded the order")
+				return nil
+			},
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recordEvent(order, v1.EventTypeWarning, "DeliveryFailed", "provider refunded the order")
+		return ctrl.Result{}, nil
 
-	// 7. (Optional) Mark as Delivered.
-	if err := updateOrderPhase(ctx, c, order, "Delivered"); err != nil {
-		return err
+	default:
+		ctrl.LoggerFrom(ctx).Info("Unrecognized delivery status, will keep polling", "status", state.Status, "order", order.Name)
+		return ctrl.Result{RequeueAfter: jitter(r.pollInterval)}, nil
 	}
-
-	fmt.Printf("Successfully placed MealMe order [%s] for TacoOrder [%s]\n", orderID, order.Name)
-	return nil
 }
 
-// Restaurant represents a taco restaurant from MealMe’s search response.
-type Restaurant struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Distance float64 `json:"distance"` // in miles
+// recordEvent emits a Kubernetes Event if the reconciler was configured with a recorder.
+func (r *TacoOrderReconciler) recordEvent(order *tacoV1alpha1.TacoOrder, eventType, reason, message string) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Event(order, eventType, reason, message)
 }
 
-// mealMeSearchResponse mirrors the MealMe API restaurant search response.
-type mealMeSearchResponse struct {
-	Restaurants []Restaurant `json:"restaurants"`
+// jitter adds up to 20% random jitter to d, so a large number of in-flight orders
+// polling on the same interval don't converge on hammering the provider in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
 }
 
-// findNearestTacoRestaurant calls MealMe’s restaurant search endpoint.
-func findNearestTacoRestaurant(address string, variety string) (*Restaurant, error) {
-	requestBody := map[string]interface{}{
-		"query":        "tacos", // searching for taco restaurants
-		"address":      address,
-		"cuisine":      "Mexican",
-		"max_distance": maxDeliveryDistance,
+// providerFor resolves the delivery.DeliveryProvider selected by order.Spec.Provider,
+// building it from the referenced credentials Secret the first time it's needed. The
+// built provider is cached by provider name, namespace, and credentials Secret name
+// together, since two orders can select the same provider while pointing at different
+// tenants' credentials Secrets.
+func (r *TacoOrderReconciler) providerFor(ctx context.Context, order *tacoV1alpha1.TacoOrder) (delivery.DeliveryProvider, error) {
+	name := order.Spec.Provider
+	if name == "" {
+		// Belt-and-suspenders: the mutating webhook persists DefaultProvider onto create,
+		// but this keeps providerFor correct for objects that predate that webhook.
+		name = tacoV1alpha1.DefaultProvider
 	}
-	payloadBytes, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, err
+
+	if p, ok := r.presetProviders[name]; ok {
+		return p, nil
 	}
 
-	req, err := http.NewRequest("POST", mealMeRestaurantSearchEndpoint, bytes.NewBuffer(payloadBytes))
+	key := providerCacheKey{namespace: order.Namespace, name: name, secretName: order.Spec.ProviderCredentialsSecretName}
+	if p, ok := r.providers[key]; ok {
+		return p, nil
+	}
+
+	credsSecret, err := getSecret(ctx, r.Client, order.Namespace, order.Spec.ProviderCredentialsSecretName)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to fetch provider credentials secret: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", os.Getenv("MEALME_API_TOKEN")))
 
-	resp, err := http.DefaultClient.Do(req)
+	provider, err := delivery.Get(name, credsSecret.Data)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if setter, ok := provider.(delivery.HTTPClientSetter); ok {
+		setter.SetHTTPClient(r.httpClient)
+	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("MealMe restaurant search failed, status code %d", resp.StatusCode)
+	if r.providers == nil {
+		r.providers = map[providerCacheKey]delivery.DeliveryProvider{}
 	}
+	r.providers[key] = provider
+	return provider, nil
+}
 
-	var mmResp mealMeSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mmResp); err != nil {
-		return nil, err
+// finalizeTacoOrder cancels an order that hasn't been delivered yet so a delete mid-flight
+// doesn't leave a paid order with nothing tracking it, then releases the finalizer.
+func (r *TacoOrderReconciler) finalizeTacoOrder(ctx context.Context, order *tacoV1alpha1.TacoOrder) error {
+	if !controllerutil.ContainsFinalizer(order, r.finalizerName) {
+		return nil
 	}
 
-	// Return the first restaurant within acceptable distance.
-	for _, r := range mmResp.Restaurants {
-		if r.Distance <= maxDeliveryDistance {
-			return &r, nil
+	if order.Status.ExternalOrderID != "" && !apimeta.IsStatusConditionTrue(order.Status.Conditions, tacoV1alpha1.ConditionDeliveryTerminal) {
+		provider, err := r.providerFor(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to resolve delivery provider for cancel: %w", err)
+		}
+		if err := provider.CancelOrder(ctx, order.Status.ExternalOrderID); err != nil {
+			return fmt.Errorf("failed to cancel in-flight order before delete: %w", err)
 		}
 	}
-	return nil, errors.New("no taco restaurants found within acceptable distance")
+
+	controllerutil.RemoveFinalizer(order, r.finalizerName)
+	return r.Update(ctx, order)
 }
 
-// mealMeOrderResponse mirrors the response from MealMe’s order endpoint.
-type mealMeOrderResponse struct {
-	OrderID string `json:"orderId"`
-	Status  string `json:"status"`
+// SetupWithManager registers this reconciler with the manager. The workqueue's rate
+// limiter backs off exponentially on repeated errors for the same order; successful
+// delivery polls are spaced by trackDelivery's jittered RequeueAfter, not by this limiter.
+func (r *TacoOrderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&tacoV1alpha1.TacoOrder{}).
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(r.requeueBackoff.BaseDelay, r.requeueBackoff.MaxDelay),
+		}).
+		Complete(r)
 }
 
-// placeTacoOrder sends a request to MealMe’s order endpoint to place the taco order.
-func placeTacoOrder(restaurantID string, quantity int, variety string, address string,
-	cardNumber string, cardExpiry string, cardCvv string) (string, error) {
+// ReconcileTacoOrder contains the main business logic for processing a TacoOrder.
+// It fetches Secrets, searches for a nearby taco restaurant, places the order via the
+// order's selected DeliveryProvider, and updates the order status. The restaurant search
+// is skipped once RestaurantFound is True for the current generation, and PlaceOrder is
+// skipped once OrderPlaced is True for any generation, so re-entry after a transient
+// error never repeats a charge.
+func (r *TacoOrderReconciler) ReconcileTacoOrder(ctx context.Context, order *tacoV1alpha1.TacoOrder) error {
+	if order.Status.ObservedGeneration == order.Generation && apimeta.IsStatusConditionTrue(order.Status.Conditions, tacoV1alpha1.ConditionOrderPlaced) {
+		return nil
+	}
 
-	requestBody := map[string]interface{}{
-		"restaurantId": restaurantID,
-		"items": []map[string]interface{}{
-			{
-				"name":     fmt.Sprintf("%s taco", variety),
-				"quantity": quantity,
-			},
-		},
-		"deliveryAddress": address,
-		"payment": map[string]string{
-			"cardNumber": cardNumber,
-			"cardExpiry": cardExpiry,
-			"cardCvv":    cardCvv,
-		},
-	}
-	payloadBytes, err := json.Marshal(requestBody)
+	provider, err := r.providerFor(ctx, order)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	req, err := http.NewRequest("POST", mealMeOrderEndpoint, bytes.NewBuffer(payloadBytes))
+	key := client.ObjectKeyFromObject(order)
+
+	// 1. Fetch payment and address Secrets.
+	paymentSecret, err := getSecret(ctx, r.Client, order.Namespace, order.Spec.PaymentSecretName)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to fetch payment secret: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", os.Getenv("MEALME_API_TOKEN")))
-
-	resp, err := http.DefaultClient.Do(req)
+	addressSecret, err := getSecret(ctx, r.Client, order.Namespace, order.Spec.AddressSecretName)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to fetch address secret: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("MealMe order failed, status code %d", resp.StatusCode)
-	}
+	// 2. Retrieve sensitive data.
+	cardNumber := string(paymentSecret.Data["cardNumber"])
+	cardExpiry := string(paymentSecret.Data["cardExpiry"])
+	cardCvv := string(paymentSecret.Data["cardCvv"])
+
+	street := string(addressSecret.Data["street"])
+	city := string(addressSecret.Data["city"])
+	state := string(addressSecret.Data["state"])
+	zip := string(addressSecret.Data["zip"])
+	fullAddress := fmt.Sprintf("%s, %s, %s %s", street, city, state, zip)
 
-	var mmResp mealMeOrderResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mmResp); err != nil {
-		return "", err
+	// 3. Search for a nearby taco restaurant, unless a prior reconcile already found one.
+	restaurantID := order.Status.RestaurantID
+	if !conditionTrueForGeneration(order.Status.Conditions, tacoV1alpha1.ConditionRestaurantFound, order.Generation) {
+		restaurant, err := provider.FindRestaurant(ctx, delivery.DeliveryQuery{
+			Address:     fullAddress,
+			Variety:     order.Spec.Variety,
+			MaxDistance: r.maxDeliveryDistance,
+		})
+		if err != nil {
+			_, _ = UpdateStatusWithRetry(ctx, r.Client, key, r.requeueBackoff, nil, func(o *tacoV1alpha1.TacoOrder) error {
+				r.setCondition(o, tacoV1alpha1.ConditionRestaurantFound, metav1.ConditionFalse, "SearchFailed", err.Error())
+				return nil
+			})
+			return fmt.Errorf("restaurant search failed: %w", err)
+		}
+
+		restaurantID = restaurant.ID
+		updated, err := UpdateStatusWithRetry(ctx, r.Client, key, r.requeueBackoff,
+			func(o *tacoV1alpha1.TacoOrder) bool {
+				return conditionTrueForGeneration(o.Status.Conditions, tacoV1alpha1.ConditionRestaurantFound, o.Generation)
+			},
+			func(o *tacoV1alpha1.TacoOrder) error {
+				o.Status.RestaurantID = restaurant.ID
+				r.setCondition(o, tacoV1alpha1.ConditionRestaurantFound, metav1.ConditionTrue, "Found", fmt.Sprintf("matched restaurant %s", restaurant.ID))
+				return nil
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to persist restaurant match: %w", err)
+		}
+		order = updated
 	}
 
-	if mmResp.Status != "ACCEPTED" && mmResp.Status != "PENDING" {
-		return "", fmt.Errorf("MealMe order not accepted; status=%s", mmResp.Status)
+	// 4. Place the taco order via the provider, unless a prior reconcile already placed it.
+	if !apimeta.IsStatusConditionTrue(order.Status.Conditions, tacoV1alpha1.ConditionOrderPlaced) {
+		receipt, err := provider.PlaceOrder(ctx, delivery.OrderRequest{
+			RestaurantID:    restaurantID,
+			Quantity:        order.Spec.Quantity,
+			Variety:         order.Spec.Variety,
+			DeliveryAddress: fullAddress,
+			CardNumber:      cardNumber,
+			CardExpiry:      cardExpiry,
+			CardCVV:         cardCvv,
+		})
+		if err != nil {
+			_, _ = UpdateStatusWithRetry(ctx, r.Client, key, r.requeueBackoff, nil, func(o *tacoV1alpha1.TacoOrder) error {
+				r.setCondition(o, tacoV1alpha1.ConditionOrderPlaced, metav1.ConditionFalse, "PlacementFailed", err.Error())
+				return nil
+			})
+			return fmt.Errorf("order placement failed: %w", err)
+		}
+
+		// Persist the external order ID before anything else so a retry after this point
+		// short-circuits on the ConditionOrderPlaced check instead of charging again.
+		// Delivery hasn't happened yet at this point, so ObservedGeneration is recorded
+		// here rather than Delivered being stamped optimistically; trackDelivery takes
+		// over from here and polls the provider until it reports a terminal state.
+		updated, err := UpdateStatusWithRetry(ctx, r.Client, key, r.requeueBackoff,
+			func(o *tacoV1alpha1.TacoOrder) bool {
+				return apimeta.IsStatusConditionTrue(o.Status.Conditions, tacoV1alpha1.ConditionOrderPlaced)
+			},
+			func(o *tacoV1alpha1.TacoOrder) error {
+				o.Status.ExternalOrderID = receipt.ProviderOrderID
+				o.Status.ObservedGeneration = o.Generation
+				r.setCondition(o, tacoV1alpha1.ConditionPaymentAccepted, metav1.ConditionTrue, "Charged", "payment accepted by provider")
+				r.setCondition(o, tacoV1alpha1.ConditionOrderPlaced, metav1.ConditionTrue, "Placed", fmt.Sprintf("provider order %s placed", receipt.ProviderOrderID))
+				return nil
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to persist external order id: %w", err)
+		}
+		order = updated
 	}
 
-	return mmResp.OrderID, nil
+	fmt.Printf("Successfully placed %s order [%s] for TacoOrder [%s]\n", provider.Name(), order.Status.ExternalOrderID, order.Name)
+	return nil
+}
+
+// setCondition sets or updates a condition on the order's status, stamping the
+// transition time from r.clock so tests can control it.
+func (r *TacoOrderReconciler) setCondition(order *tacoV1alpha1.TacoOrder, condType string, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&order.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: order.Generation,
+		LastTransitionTime: metav1.NewTime(r.clock.Now()),
+	})
+}
+
+// conditionTrueForGeneration reports whether condType is currently True and was last set
+// against generation. setCondition stamps a condition's own ObservedGeneration on every
+// write, which (unlike TacoOrderStatus.ObservedGeneration, only written once the order is
+// placed) tracks the generation the condition itself was evaluated for — so a gate built
+// on this doesn't stay permanently open on every retry before ObservedGeneration catches
+// up.
+func conditionTrueForGeneration(conditions []metav1.Condition, condType string, generation int64) bool {
+	cond := apimeta.FindStatusCondition(conditions, condType)
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == generation
 }
 
 // getSecret retrieves a Secret resource by name.
@@ -247,12 +467,6 @@ func getSecret(ctx context.Context, c client.Client, namespace, secretName strin
 	return secret, err
 }
 
-// updateOrderPhase updates the TacoOrder status.phase field.
-func updateOrderPhase(ctx context.Context, c client.Client, order *tacoV1alpha1.TacoOrder, phase string) error {
-	order.Status.Phase = phase
-	return c.Status().Update(ctx, order)
-}
-
 // main sets up the manager, registers the controller, and starts the manager.
 func main() {
 	// Set up logging.
@@ -269,16 +483,67 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Register the TacoOrderReconciler with the manager.
-	if err = (&TacoOrderReconciler{
-		Client: mgr.GetClient(),
-	}).SetupWithManager(mgr); err != nil {
+	// Watch tacoorders.taco.io so we can fail fast on a cluster where it hasn't been
+	// installed yet, instead of running blind.
+	crdGate := bootstrap.NewCRDReadinessGate(tacoOrderCRDName)
+	if err := crdGate.SetupWithManager(mgr); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to set up CRD readiness gate: %v\n", err)
+		os.Exit(1)
+	}
+	if err := mgr.Add(crdGate); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to register CRD readiness gate: %v\n", err)
+		os.Exit(1)
+	}
+
+	decoder := admission.NewDecoder(scheme)
+	validator := tacowebhook.NewTacoOrderValidator(mgr.GetClient(), decoder, tacowebhook.DefaultAllowedVarieties, tacowebhook.DefaultMaxQuantity)
+	mgr.GetWebhookServer().Register("/validate-taco-io-v1alpha1-tacoorder", &webhook.Admission{Handler: validator})
+
+	defaulter := tacowebhook.NewTacoOrderDefaulter(decoder)
+	mgr.GetWebhookServer().Register("/mutate-taco-io-v1alpha1-tacoorder", &webhook.Admission{Handler: defaulter})
+
+	ctx := ctrl.SetupSignalHandler()
+
+	// Start the manager in the background so the CRD readiness gate's own watch can run:
+	// its Reconcile needs the manager's cache started before it can ever observe
+	// tacoorders.taco.io becoming Established.
+	mgrErrCh := make(chan error, 1)
+	go func() {
+		fmt.Println("Starting manager")
+		mgrErrCh <- mgr.Start(ctx)
+	}()
+
+	// Block here, before the TacoOrder controller's watch is registered, so it never
+	// starts reconciling against a CRD that isn't installed yet.
+	select {
+	case <-crdGate.Ready():
+	case err := <-mgrErrCh:
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem running manager: %v\n", err)
+		}
+		os.Exit(1)
+	case <-ctx.Done():
+		fmt.Fprintln(os.Stderr, "shutting down before CRD readiness gate became ready")
+		os.Exit(1)
+	}
+
+	reconciler, err := NewTacoOrderReconciler(mgr.GetClient(),
+		WithEventRecorder(mgr.GetEventRecorderFor("taco-operator")),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to configure controller: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Register the TacoOrderReconciler with the manager now that tacoorders.taco.io is
+	// confirmed Established. controller-runtime starts a controller added after mgr.Start
+	// has already begun immediately, rather than requiring it to be registered upfront.
+	if err := reconciler.SetupWithManager(mgr); err != nil {
 		fmt.Fprintf(os.Stderr, "unable to create controller: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("Starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := <-mgrErrCh; err != nil {
 		fmt.Fprintf(os.Stderr, "problem running manager: %v\n", err)
 		os.Exit(1)
 	}
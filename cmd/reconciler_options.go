@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+
+	"github.com/cwarje/taco-operator/pkg/delivery"
+)
+
+// Clock abstracts time.Now so tests can control the timestamps the reconciler stamps
+// onto conditions.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the Clock used in production.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// BackoffConfig controls how the reconciler spaces out requeues after a failed
+// reconcile.
+type BackoffConfig struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Steps     int
+}
+
+// DefaultBackoffConfig is used when no BackoffConfig is supplied via WithRequeueBackoff.
+var DefaultBackoffConfig = BackoffConfig{
+	BaseDelay: time.Second,
+	MaxDelay:  30 * time.Second,
+	Steps:     5,
+}
+
+// Option configures a TacoOrderReconciler built by NewTacoOrderReconciler.
+type Option func(*TacoOrderReconciler) error
+
+// WithHTTPClient overrides the *http.Client used for every outbound call to a delivery
+// provider that supports delivery.HTTPClientSetter.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(r *TacoOrderReconciler) error {
+		if hc == nil {
+			return errors.New("taco-operator: http client must not be nil")
+		}
+		r.httpClient = hc
+		return nil
+	}
+}
+
+// WithDeliveryProvider pre-registers a resolved delivery.DeliveryProvider under name,
+// bypassing the Secret-backed lookup in providerFor. Tests use this to inject a fake
+// provider.
+func WithDeliveryProvider(name string, p delivery.DeliveryProvider) Option {
+	return func(r *TacoOrderReconciler) error {
+		if name == "" {
+			return errors.New("taco-operator: delivery provider name must not be empty")
+		}
+		if p == nil {
+			return errors.New("taco-operator: delivery provider must not be nil")
+		}
+		if r.presetProviders == nil {
+			r.presetProviders = map[string]delivery.DeliveryProvider{}
+		}
+		r.presetProviders[name] = p
+		return nil
+	}
+}
+
+// WithClock overrides the Clock used to stamp condition transition times.
+func WithClock(c Clock) Option {
+	return func(r *TacoOrderReconciler) error {
+		if c == nil {
+			return errors.New("taco-operator: clock must not be nil")
+		}
+		r.clock = c
+		return nil
+	}
+}
+
+// WithMaxDeliveryDistance overrides how far, in miles, a matching restaurant may be.
+func WithMaxDeliveryDistance(miles float64) Option {
+	return func(r *TacoOrderReconciler) error {
+		if miles <= 0 {
+			return fmt.Errorf("taco-operator: max delivery distance must be positive, got %v", miles)
+		}
+		r.maxDeliveryDistance = miles
+		return nil
+	}
+}
+
+// WithRequeueBackoff overrides the backoff schedule used to space out requeues.
+func WithRequeueBackoff(cfg BackoffConfig) Option {
+	return func(r *TacoOrderReconciler) error {
+		if cfg.Steps <= 0 {
+			return errors.New("taco-operator: requeue backoff steps must be positive")
+		}
+		r.requeueBackoff = cfg
+		return nil
+	}
+}
+
+// WithEventRecorder overrides the record.EventRecorder used to emit Kubernetes Events.
+func WithEventRecorder(rec record.EventRecorder) Option {
+	return func(r *TacoOrderReconciler) error {
+		if rec == nil {
+			return errors.New("taco-operator: event recorder must not be nil")
+		}
+		r.recorder = rec
+		return nil
+	}
+}
+
+// WithFinalizerName overrides the finalizer the reconciler adds on its first reconcile of
+// an order and clears on delete.
+func WithFinalizerName(name string) Option {
+	return func(r *TacoOrderReconciler) error {
+		if name == "" {
+			return errors.New("taco-operator: finalizer name must not be empty")
+		}
+		r.finalizerName = name
+		return nil
+	}
+}
+
+// WithPollInterval overrides how often a placed order's delivery status is polled.
+func WithPollInterval(d time.Duration) Option {
+	return func(r *TacoOrderReconciler) error {
+		if d <= 0 {
+			return fmt.Errorf("taco-operator: poll interval must be positive, got %v", d)
+		}
+		r.pollInterval = d
+		return nil
+	}
+}
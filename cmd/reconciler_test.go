@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tacoV1alpha1 "github.com/cwarje/taco-operator/api/v1alpha1"
+	deliveryfake "github.com/cwarje/taco-operator/pkg/delivery/fake"
+)
+
+// fixedClock is a Clock that always reports the same instant, so assertions on
+// condition timestamps don't have to tolerate wall-clock skew.
+type fixedClock struct{ t time.Time }
+
+func (c fixedClock) Now() time.Time { return c.t }
+
+// newTestOrder returns a TacoOrder and the Secrets its Spec references, ready to be
+// handed to a fake client.
+func newTestOrder(name string) (*tacoV1alpha1.TacoOrder, []client.Object) {
+	order := &tacoV1alpha1.TacoOrder{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1},
+		Spec: tacoV1alpha1.TacoOrderSpec{
+			Quantity:                      2,
+			PaymentSecretName:             name + "-payment",
+			AddressSecretName:             name + "-address",
+			Provider:                      "fake",
+			ProviderCredentialsSecretName: name + "-creds",
+		},
+	}
+
+	secrets := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-payment", Namespace: "default"},
+			Data: map[string][]byte{
+				"cardNumber": []byte("4242424242424242"),
+				"cardExpiry": []byte("12/30"),
+				"cardCvv":    []byte("123"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-address", Namespace: "default"},
+			Data: map[string][]byte{
+				"street": []byte("1 Taco Way"),
+				"city":   []byte("Austin"),
+				"state":  []byte("TX"),
+				"zip":    []byte("78701"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-creds", Namespace: "default"},
+			Data:       map[string][]byte{"apiToken": []byte("token")},
+		},
+	}
+
+	return order, secrets
+}
+
+// newTestReconciler builds a TacoOrderReconciler backed by a fake client seeded with
+// order and its Secrets, wired to provider under the name "fake". Events it records can
+// be read off recorder.Events.
+func newTestReconciler(t *testing.T, order *tacoV1alpha1.TacoOrder, secrets []client.Object, provider *deliveryfake.Provider) (*TacoOrderReconciler, client.Client, *record.FakeRecorder) {
+	t.Helper()
+
+	objs := append([]client.Object{order}, secrets...)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&tacoV1alpha1.TacoOrder{}).
+		WithObjects(objs...).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r, err := NewTacoOrderReconciler(c,
+		WithDeliveryProvider("fake", provider),
+		WithClock(fixedClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}),
+		WithEventRecorder(recorder),
+	)
+	if err != nil {
+		t.Fatalf("NewTacoOrderReconciler: %v", err)
+	}
+	return r, c, recorder
+}
+
+// TestReconcileTacoOrder_IdempotentReplay exercises the replay path added in
+// chunk0-1/chunk0-4: once an order has been placed, a second ReconcileTacoOrder call for
+// the same generation must not place (and thus not charge) it again.
+func TestReconcileTacoOrder_IdempotentReplay(t *testing.T) {
+	order, secrets := newTestOrder("replay")
+	provider := deliveryfake.New()
+	r, c, _ := newTestReconciler(t, order, secrets, provider)
+	ctx := context.Background()
+
+	if err := r.ReconcileTacoOrder(ctx, order); err != nil {
+		t.Fatalf("first ReconcileTacoOrder: %v", err)
+	}
+	if provider.PlaceCalls != 1 {
+		t.Fatalf("PlaceCalls after first reconcile = %d, want 1", provider.PlaceCalls)
+	}
+
+	var current tacoV1alpha1.TacoOrder
+	if err := c.Get(ctx, client.ObjectKeyFromObject(order), &current); err != nil {
+		t.Fatalf("Get after first reconcile: %v", err)
+	}
+	if !apimeta.IsStatusConditionTrue(current.Status.Conditions, tacoV1alpha1.ConditionOrderPlaced) {
+		t.Fatalf("ConditionOrderPlaced not set True after first reconcile")
+	}
+
+	if err := r.ReconcileTacoOrder(ctx, &current); err != nil {
+		t.Fatalf("second ReconcileTacoOrder: %v", err)
+	}
+	if provider.PlaceCalls != 1 {
+		t.Fatalf("PlaceCalls after second reconcile = %d, want still 1 (no re-placement)", provider.PlaceCalls)
+	}
+}
+
+// TestTrackDelivery_StateMapping covers the provider-state-to-condition mapping added in
+// chunk0-5, including that failed/refunded orders are marked terminal so they stop being
+// routed back into trackDelivery.
+func TestTrackDelivery_StateMapping(t *testing.T) {
+	cases := []struct {
+		providerStatus  string
+		wantRequeue     bool
+		wantDelivered   metav1.ConditionStatus
+		wantTerminal    bool
+		wantEventReason string
+	}{
+		{providerStatus: "preparing", wantRequeue: true, wantDelivered: metav1.ConditionFalse, wantTerminal: false, wantEventReason: "DeliveryPreparing"},
+		{providerStatus: "en_route", wantRequeue: true, wantDelivered: metav1.ConditionFalse, wantTerminal: false, wantEventReason: "DeliveryEnRoute"},
+		{providerStatus: "delivered", wantRequeue: false, wantDelivered: metav1.ConditionTrue, wantTerminal: true, wantEventReason: "DeliveryDelivered"},
+		{providerStatus: "failed", wantRequeue: false, wantDelivered: metav1.ConditionFalse, wantTerminal: true, wantEventReason: "DeliveryFailed"},
+		{providerStatus: "refunded", wantRequeue: false, wantDelivered: metav1.ConditionFalse, wantTerminal: true, wantEventReason: "DeliveryFailed"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.providerStatus, func(t *testing.T) {
+			order, secrets := newTestOrder("track-" + tc.providerStatus)
+			order.Status.ExternalOrderID = "provider-order-1"
+			apimeta.SetStatusCondition(&order.Status.Conditions, metav1.Condition{
+				Type: tacoV1alpha1.ConditionOrderPlaced, Status: metav1.ConditionTrue, Reason: "Placed", Message: "placed",
+			})
+
+			provider := deliveryfake.New()
+			provider.State.Status = tc.providerStatus
+
+			r, c, recorder := newTestReconciler(t, order, secrets, provider)
+			ctx := context.Background()
+
+			result, err := r.trackDelivery(ctx, order)
+			if err != nil {
+				t.Fatalf("trackDelivery: %v", err)
+			}
+			if gotRequeue := result.RequeueAfter > 0; gotRequeue != tc.wantRequeue {
+				t.Fatalf("RequeueAfter set = %v, want %v", gotRequeue, tc.wantRequeue)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if !strings.Contains(event, tc.wantEventReason) {
+					t.Fatalf("recorded event %q, want it to mention reason %q", event, tc.wantEventReason)
+				}
+			default:
+				t.Fatalf("no event recorded, want one mentioning reason %q", tc.wantEventReason)
+			}
+
+			var current tacoV1alpha1.TacoOrder
+			if err := c.Get(ctx, client.ObjectKeyFromObject(order), &current); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got := apimeta.IsStatusConditionTrue(current.Status.Conditions, tacoV1alpha1.ConditionDeliveryTerminal); got != tc.wantTerminal {
+				t.Fatalf("ConditionDeliveryTerminal = %v, want %v", got, tc.wantTerminal)
+			}
+
+			// preparing/en_route don't touch ConditionDelivered at all; it's only ever
+			// written once a terminal state is reached.
+			if !tc.wantTerminal {
+				if got := apimeta.FindStatusCondition(current.Status.Conditions, tacoV1alpha1.ConditionDelivered); got != nil {
+					t.Fatalf("ConditionDelivered = %v, want unset", got)
+				}
+				return
+			}
+			if got := apimeta.FindStatusCondition(current.Status.Conditions, tacoV1alpha1.ConditionDelivered); got == nil || got.Status != tc.wantDelivered {
+				t.Fatalf("ConditionDelivered = %v, want %v", got, tc.wantDelivered)
+			}
+
+			// A terminal state must stop Reconcile from routing back into trackDelivery,
+			// so a reconcile triggered for an unrelated reason (e.g. a metadata edit)
+			// doesn't poll the provider again.
+			statusCallsBefore := provider.StatusCalls
+			if apimeta.IsStatusConditionTrue(current.Status.Conditions, tacoV1alpha1.ConditionOrderPlaced) &&
+				!apimeta.IsStatusConditionTrue(current.Status.Conditions, tacoV1alpha1.ConditionDeliveryTerminal) {
+				t.Fatalf("order still routes to trackDelivery after reaching a terminal state")
+			}
+			if provider.StatusCalls != statusCallsBefore {
+				t.Fatalf("GetOrderStatus called again after terminal state reached")
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tacoV1alpha1 "github.com/cwarje/taco-operator/api/v1alpha1"
+)
+
+// UpdateStatusWithRetry fetches the current TacoOrder, hands a deep copy of it to
+// tryUpdate to mutate Status, and persists the result. Modeled on etcd3's tryUpdate
+// closure pattern: on a conflict it re-fetches the current object and retries tryUpdate
+// against it rather than failing the whole reconcile, because a plain Get-mutate-Update
+// silently drops the write the moment two reconciles race. If alreadyApplied reports true
+// against the freshly fetched object, the update is skipped entirely and that object is
+// returned as-is, avoiding a needless write.
+func UpdateStatusWithRetry(
+	ctx context.Context,
+	c client.Client,
+	key client.ObjectKey,
+	backoff BackoffConfig,
+	alreadyApplied func(*tacoV1alpha1.TacoOrder) bool,
+	tryUpdate func(*tacoV1alpha1.TacoOrder) error,
+) (*tacoV1alpha1.TacoOrder, error) {
+	delay := backoff.BaseDelay
+
+	for attempt := 0; ; attempt++ {
+		var current tacoV1alpha1.TacoOrder
+		if err := c.Get(ctx, key, &current); err != nil {
+			return nil, err
+		}
+
+		if alreadyApplied != nil && alreadyApplied(&current) {
+			return &current, nil
+		}
+
+		updated := current.DeepCopy()
+		if err := tryUpdate(updated); err != nil {
+			return nil, err
+		}
+
+		err := c.Status().Update(ctx, updated)
+		if err == nil {
+			return updated, nil
+		}
+		if !apierrors.IsConflict(err) {
+			return nil, err
+		}
+		if attempt+1 >= backoff.Steps {
+			return nil, fmt.Errorf("update status: still conflicting after %d attempts: %w", backoff.Steps, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > backoff.MaxDelay {
+			delay = backoff.MaxDelay
+		}
+	}
+}
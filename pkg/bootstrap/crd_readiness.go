@@ -0,0 +1,93 @@
+// Package bootstrap contains startup gates run before the operator begins serving.
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CRDReadinessGate watches a single CustomResourceDefinition and closes Ready() once it
+// has reported Established=True and NamesAccepted=True. Registering it as a
+// manager.Runnable only delays its own Start from returning, since controller-runtime
+// starts every registered Runnable concurrently; callers that want to fail fast on a
+// misconfigured cluster instead of starting other controllers blind must also block on
+// Ready() (or ctx.Done()) themselves before registering those controllers, as main() does
+// for the TacoOrder controller.
+type CRDReadinessGate struct {
+	client.Client
+
+	// Name is the CustomResourceDefinition to wait on, e.g. "tacoorders.taco.io".
+	Name string
+
+	once  sync.Once
+	ready chan struct{}
+}
+
+// NewCRDReadinessGate builds a gate that waits on the named CRD.
+func NewCRDReadinessGate(name string) *CRDReadinessGate {
+	return &CRDReadinessGate{Name: name, ready: make(chan struct{})}
+}
+
+// Ready is closed once the watched CRD reports Established=True and NamesAccepted=True.
+func (g *CRDReadinessGate) Ready() <-chan struct{} {
+	return g.ready
+}
+
+// Reconcile implements reconcile.Reconciler. It only acts on the CRD named g.Name and
+// closes g.ready the first time that CRD reports itself Established.
+func (g *CRDReadinessGate) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.Name != g.Name {
+		return ctrl.Result{}, nil
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := g.Get(ctx, types.NamespacedName{Name: g.Name}, &crd); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if isEstablished(&crd) {
+		g.once.Do(func() { close(g.ready) })
+	}
+	return ctrl.Result{}, nil
+}
+
+// isEstablished reports whether crd has both the Established and NamesAccepted
+// conditions set to True.
+func isEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	established, namesAccepted := false, false
+	for _, cond := range crd.Status.Conditions {
+		switch cond.Type {
+		case apiextensionsv1.Established:
+			established = cond.Status == apiextensionsv1.ConditionTrue
+		case apiextensionsv1.NamesAccepted:
+			namesAccepted = cond.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return established && namesAccepted
+}
+
+// SetupWithManager registers the gate's CRD watch with the manager.
+func (g *CRDReadinessGate) SetupWithManager(mgr ctrl.Manager) error {
+	g.Client = mgr.GetClient()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiextensionsv1.CustomResourceDefinition{}).
+		Complete(g)
+}
+
+// Start implements manager.Runnable: it blocks until the watched CRD becomes Established
+// or ctx is done. This only delays the gate's own Runnable from returning; it does not by
+// itself hold back any other controller registered with the same manager.
+func (g *CRDReadinessGate) Start(ctx context.Context) error {
+	select {
+	case <-g.ready:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("still waiting for CustomResourceDefinition %q to become Established: %w", g.Name, ctx.Err())
+	}
+}
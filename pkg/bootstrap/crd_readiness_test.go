@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestIsEstablished(t *testing.T) {
+	cases := []struct {
+		name       string
+		conditions []apiextensionsv1.CustomResourceDefinitionCondition
+		want       bool
+	}{
+		{
+			name: "established and names accepted",
+			conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+				{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+			},
+			want: true,
+		},
+		{
+			name: "names accepted only",
+			conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+			},
+			want: false,
+		},
+		{
+			name: "established false",
+			conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionFalse},
+				{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+			},
+			want: false,
+		},
+		{
+			name: "no conditions",
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			crd := &apiextensionsv1.CustomResourceDefinition{
+				Status: apiextensionsv1.CustomResourceDefinitionStatus{Conditions: tc.conditions},
+			}
+			if got := isEstablished(crd); got != tc.want {
+				t.Fatalf("isEstablished() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package doordash is a placeholder delivery.DeliveryProvider for DoorDash. It registers
+// under "doordash" so Spec.Provider can reference it, but every method returns an error.
+package doordash
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cwarje/taco-operator/pkg/delivery"
+)
+
+var errNotImplemented = errors.New("doordash: provider not yet implemented")
+
+func init() {
+	delivery.Register("doordash", func(credentials map[string][]byte) (delivery.DeliveryProvider, error) {
+		return &Provider{}, nil
+	})
+}
+
+// Provider is a stub implementation of delivery.DeliveryProvider.
+type Provider struct{}
+
+// Name returns "doordash".
+func (p *Provider) Name() string { return "doordash" }
+
+func (p *Provider) FindRestaurant(ctx context.Context, query delivery.DeliveryQuery) (delivery.Restaurant, error) {
+	return delivery.Restaurant{}, errNotImplemented
+}
+
+func (p *Provider) PlaceOrder(ctx context.Context, req delivery.OrderRequest) (delivery.OrderReceipt, error) {
+	return delivery.OrderReceipt{}, errNotImplemented
+}
+
+func (p *Provider) GetOrderStatus(ctx context.Context, providerOrderID string) (delivery.OrderState, error) {
+	return delivery.OrderState{}, errNotImplemented
+}
+
+func (p *Provider) CancelOrder(ctx context.Context, providerOrderID string) error {
+	return errNotImplemented
+}
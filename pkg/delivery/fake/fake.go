@@ -0,0 +1,69 @@
+// Package fake provides an in-memory delivery.DeliveryProvider for tests.
+package fake
+
+import (
+	"context"
+
+	"github.com/cwarje/taco-operator/pkg/delivery"
+)
+
+func init() {
+	delivery.Register("fake", func(credentials map[string][]byte) (delivery.DeliveryProvider, error) {
+		return New(), nil
+	})
+}
+
+// Provider is a scriptable delivery.DeliveryProvider for tests. Callers can set its
+// fields directly to control what each method returns.
+type Provider struct {
+	Restaurant delivery.Restaurant
+	Receipt    delivery.OrderReceipt
+	State      delivery.OrderState
+
+	FindErr   error
+	PlaceErr  error
+	StatusErr error
+	CancelErr error
+
+	// Canceled records the providerOrderIDs passed to CancelOrder, in order.
+	Canceled []string
+
+	// PlaceCalls counts PlaceOrder invocations, so tests can assert a retried reconcile
+	// didn't re-place (and re-charge) an order.
+	PlaceCalls int
+
+	// StatusCalls counts GetOrderStatus invocations, so tests can assert a terminal
+	// delivery state stops further polling.
+	StatusCalls int
+}
+
+// New returns a Provider with reasonable defaults for a happy-path order.
+func New() *Provider {
+	return &Provider{
+		Restaurant: delivery.Restaurant{ID: "fake-restaurant", Name: "Fake Tacos", Distance: 1},
+		Receipt:    delivery.OrderReceipt{ProviderOrderID: "fake-order", Status: "ACCEPTED"},
+		State:      delivery.OrderState{Status: "preparing"},
+	}
+}
+
+// Name returns "fake".
+func (p *Provider) Name() string { return "fake" }
+
+func (p *Provider) FindRestaurant(ctx context.Context, query delivery.DeliveryQuery) (delivery.Restaurant, error) {
+	return p.Restaurant, p.FindErr
+}
+
+func (p *Provider) PlaceOrder(ctx context.Context, req delivery.OrderRequest) (delivery.OrderReceipt, error) {
+	p.PlaceCalls++
+	return p.Receipt, p.PlaceErr
+}
+
+func (p *Provider) GetOrderStatus(ctx context.Context, providerOrderID string) (delivery.OrderState, error) {
+	p.StatusCalls++
+	return p.State, p.StatusErr
+}
+
+func (p *Provider) CancelOrder(ctx context.Context, providerOrderID string) error {
+	p.Canceled = append(p.Canceled, providerOrderID)
+	return p.CancelErr
+}
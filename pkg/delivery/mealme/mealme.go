@@ -0,0 +1,204 @@
+// Package mealme implements delivery.DeliveryProvider against the MealMe API.
+package mealme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cwarje/taco-operator/pkg/delivery"
+)
+
+const (
+	restaurantSearchEndpoint = "https://api.mealme.ai/v1/restaurants/search"
+	orderEndpoint            = "https://api.mealme.ai/v1/orders"
+	orderStatusEndpointFmt   = "https://api.mealme.ai/v1/orders/%s"
+	cancelEndpointFmt        = "https://api.mealme.ai/v1/orders/%s/cancel"
+)
+
+func init() {
+	delivery.Register("mealme", func(credentials map[string][]byte) (delivery.DeliveryProvider, error) {
+		token := string(credentials["apiToken"])
+		if token == "" {
+			return nil, errors.New("mealme: credentials secret missing \"apiToken\" key")
+		}
+		return &Provider{apiToken: token, httpClient: http.DefaultClient}, nil
+	})
+}
+
+// Provider implements delivery.DeliveryProvider against the MealMe API.
+type Provider struct {
+	apiToken   string
+	httpClient *http.Client
+}
+
+// Name returns "mealme".
+func (p *Provider) Name() string { return "mealme" }
+
+// SetHTTPClient overrides the *http.Client used for outbound requests.
+func (p *Provider) SetHTTPClient(hc *http.Client) { p.httpClient = hc }
+
+// searchResponse mirrors the MealMe API restaurant search response.
+type searchResponse struct {
+	Restaurants []delivery.Restaurant `json:"restaurants"`
+}
+
+// FindRestaurant calls MealMe's restaurant search endpoint.
+func (p *Provider) FindRestaurant(ctx context.Context, query delivery.DeliveryQuery) (delivery.Restaurant, error) {
+	maxDistance := query.MaxDistance
+	if maxDistance == 0 {
+		maxDistance = delivery.DefaultMaxDeliveryDistance
+	}
+
+	requestBody := map[string]interface{}{
+		"query":        "tacos", // searching for taco restaurants
+		"address":      query.Address,
+		"cuisine":      "Mexican",
+		"max_distance": maxDistance,
+	}
+	payloadBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return delivery.Restaurant{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", restaurantSearchEndpoint, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return delivery.Restaurant{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.apiToken))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return delivery.Restaurant{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return delivery.Restaurant{}, fmt.Errorf("MealMe restaurant search failed, status code %d", resp.StatusCode)
+	}
+
+	var mmResp searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mmResp); err != nil {
+		return delivery.Restaurant{}, err
+	}
+
+	// Return the first restaurant within acceptable distance.
+	for _, r := range mmResp.Restaurants {
+		if r.Distance <= maxDistance {
+			return r, nil
+		}
+	}
+	return delivery.Restaurant{}, errors.New("no taco restaurants found within acceptable distance")
+}
+
+// orderResponse mirrors the response from MealMe's order endpoint.
+type orderResponse struct {
+	OrderID string `json:"orderId"`
+	Status  string `json:"status"`
+}
+
+// PlaceOrder sends a request to MealMe's order endpoint to place the taco order.
+func (p *Provider) PlaceOrder(ctx context.Context, req delivery.OrderRequest) (delivery.OrderReceipt, error) {
+	requestBody := map[string]interface{}{
+		"restaurantId": req.RestaurantID,
+		"items": []map[string]interface{}{
+			{
+				"name":     fmt.Sprintf("%s taco", req.Variety),
+				"quantity": req.Quantity,
+			},
+		},
+		"deliveryAddress": req.DeliveryAddress,
+		"payment": map[string]string{
+			"cardNumber": req.CardNumber,
+			"cardExpiry": req.CardExpiry,
+			"cardCvv":    req.CardCVV,
+		},
+	}
+	payloadBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return delivery.OrderReceipt{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", orderEndpoint, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return delivery.OrderReceipt{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.apiToken))
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return delivery.OrderReceipt{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return delivery.OrderReceipt{}, fmt.Errorf("MealMe order failed, status code %d", resp.StatusCode)
+	}
+
+	var mmResp orderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mmResp); err != nil {
+		return delivery.OrderReceipt{}, err
+	}
+
+	if mmResp.Status != "ACCEPTED" && mmResp.Status != "PENDING" {
+		return delivery.OrderReceipt{}, fmt.Errorf("MealMe order not accepted; status=%s", mmResp.Status)
+	}
+
+	return delivery.OrderReceipt{ProviderOrderID: mmResp.OrderID, Status: mmResp.Status}, nil
+}
+
+// orderStatusResponse mirrors the response from MealMe's order status endpoint.
+type orderStatusResponse struct {
+	Status string `json:"status"`
+}
+
+// GetOrderStatus calls MealMe's order status endpoint.
+func (p *Provider) GetOrderStatus(ctx context.Context, providerOrderID string) (delivery.OrderState, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(orderStatusEndpointFmt, providerOrderID), nil)
+	if err != nil {
+		return delivery.OrderState{}, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.apiToken))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return delivery.OrderState{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return delivery.OrderState{}, fmt.Errorf("MealMe order status check failed, status code %d", resp.StatusCode)
+	}
+
+	var mmResp orderStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mmResp); err != nil {
+		return delivery.OrderState{}, err
+	}
+
+	return delivery.OrderState{Status: mmResp.Status}, nil
+}
+
+// CancelOrder calls MealMe's order cancel endpoint.
+func (p *Provider) CancelOrder(ctx context.Context, providerOrderID string) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf(cancelEndpointFmt, providerOrderID), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.apiToken))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("MealMe order cancel failed, status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Package delivery defines the extension point used by the TacoOrder controller to talk
+// to a food delivery provider, and a registry that lets implementations self-register.
+package delivery
+
+import (
+	"context"
+	"net/http"
+)
+
+// DefaultMaxDeliveryDistance is used when a DeliveryQuery doesn't specify one.
+const DefaultMaxDeliveryDistance = 5.0 // in miles
+
+// DeliveryQuery describes what the caller is looking for when asking a provider to find
+// a restaurant.
+type DeliveryQuery struct {
+	// Address is the full delivery address to search near.
+	Address string
+	// Variety is the requested taco variety (e.g. "carnitas", "al pastor").
+	Variety string
+	// MaxDistance bounds how far, in miles, a matching restaurant may be.
+	MaxDistance float64
+}
+
+// Restaurant is a single result from DeliveryProvider.FindRestaurant.
+type Restaurant struct {
+	ID       string
+	Name     string
+	Distance float64 // in miles
+}
+
+// OrderRequest carries everything a provider needs to place an order.
+type OrderRequest struct {
+	RestaurantID    string
+	Quantity        int
+	Variety         string
+	DeliveryAddress string
+	CardNumber      string
+	CardExpiry      string
+	CardCVV         string
+}
+
+// OrderReceipt is returned once a provider has accepted an order.
+type OrderReceipt struct {
+	// ProviderOrderID identifies the order in the provider's own system.
+	ProviderOrderID string
+	// Status is the provider's own acceptance status (e.g. "ACCEPTED", "PENDING").
+	Status string
+}
+
+// OrderState is the provider's current view of a previously placed order.
+type OrderState struct {
+	// Status is one of "preparing", "en_route", "delivered", "failed", "refunded".
+	Status string
+}
+
+// DeliveryProvider is implemented by each food delivery integration (MealMe, DoorDash,
+// UberEats, ...). Implementations register a Factory under their name via Register so
+// the controller can select one by Spec.Provider.
+type DeliveryProvider interface {
+	// Name returns the provider's registered name.
+	Name() string
+	// FindRestaurant locates a restaurant matching query.
+	FindRestaurant(ctx context.Context, query DeliveryQuery) (Restaurant, error)
+	// PlaceOrder submits an order and charges the supplied payment details.
+	PlaceOrder(ctx context.Context, req OrderRequest) (OrderReceipt, error)
+	// GetOrderStatus returns the provider's current state for a previously placed order.
+	GetOrderStatus(ctx context.Context, providerOrderID string) (OrderState, error)
+	// CancelOrder cancels a previously placed order.
+	CancelOrder(ctx context.Context, providerOrderID string) error
+}
+
+// HTTPClientSetter is implemented by providers that call out over HTTP and can accept a
+// caller-supplied *http.Client instead of defaulting to http.DefaultClient. The
+// controller uses this to route every outbound request through one configurable client.
+type HTTPClientSetter interface {
+	SetHTTPClient(*http.Client)
+}
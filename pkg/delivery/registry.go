@@ -0,0 +1,47 @@
+package delivery
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Factory builds a DeliveryProvider from the data of the Secret referenced by a
+// TacoOrder's Spec.ProviderCredentialsSecretName.
+type Factory func(credentials map[string][]byte) (DeliveryProvider, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register registers a DeliveryProvider factory under name. Implementations call this
+// from an init() so that importing the package for its side effects is enough to make
+// the provider selectable via Spec.Provider.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = factory
+}
+
+// Get builds the named provider using the given credentials. It returns an error if no
+// provider is registered under name.
+func Get(name string, credentials map[string][]byte) (DeliveryProvider, error) {
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("delivery: no provider registered under name %q", name)
+	}
+	return factory(credentials)
+}
+
+// Names returns the names of all registered providers.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	tacoV1alpha1 "github.com/cwarje/taco-operator/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-taco-io-v1alpha1-tacoorder,mutating=true,failurePolicy=fail,sideEffects=None,groups=taco.io,resources=tacoorders,verbs=create,versions=v1alpha1,name=mtacoorder.taco.io,admissionReviewVersions=v1
+
+// TacoOrderDefaulter defaults Spec.Provider to tacoV1alpha1.DefaultProvider on create when
+// left unset, persisting the choice onto the stored object rather than leaving it to be
+// defaulted transiently, and differently, by every reconcile.
+type TacoOrderDefaulter struct {
+	decoder admission.Decoder
+}
+
+var _ admission.Handler = &TacoOrderDefaulter{}
+
+// NewTacoOrderDefaulter builds a TacoOrderDefaulter decoding requests with d.
+func NewTacoOrderDefaulter(d admission.Decoder) *TacoOrderDefaulter {
+	return &TacoOrderDefaulter{decoder: d}
+}
+
+// Handle implements admission.Handler.
+func (d *TacoOrderDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var order tacoV1alpha1.TacoOrder
+	if err := d.decoder.Decode(req, &order); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if order.Spec.Provider != "" {
+		return admission.Allowed("")
+	}
+
+	order.Spec.Provider = tacoV1alpha1.DefaultProvider
+	marshaled, err := json.Marshal(order)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
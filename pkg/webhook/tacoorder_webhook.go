@@ -0,0 +1,127 @@
+// Package webhook implements the admission webhooks for the TacoOrder CRD.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	tacoV1alpha1 "github.com/cwarje/taco-operator/api/v1alpha1"
+)
+
+// DefaultAllowedVarieties is used by main() when no operator-specific allowlist is
+// configured.
+var DefaultAllowedVarieties = []string{"carnitas", "al pastor", "carne asada", "chicken", "veggie", "fish"}
+
+// DefaultMaxQuantity is used by main() when no operator-specific bound is configured. The
+// kubebuilder Minimum=1 marker on Quantity has no matching upper bound, so without this an
+// order for an absurd quantity would sail through admission.
+const DefaultMaxQuantity = 50
+
+// +kubebuilder:webhook:path=/validate-taco-io-v1alpha1-tacoorder,mutating=false,failurePolicy=fail,sideEffects=None,groups=taco.io,resources=tacoorders,verbs=create;update,versions=v1alpha1,name=vtacoorder.taco.io,admissionReviewVersions=v1
+
+// TacoOrderValidator validates TacoOrder CREATE/UPDATE admission requests: it resolves
+// the payment and address Secrets and checks their expected keys are present, validates
+// Variety against AllowedVarieties and Quantity against MaxQuantity, and rejects Spec
+// mutations once an order has already been placed with a provider.
+type TacoOrderValidator struct {
+	client.Client
+
+	// AllowedVarieties is the set of Spec.Variety values that will be admitted. An empty
+	// set allows any non-empty variety.
+	AllowedVarieties []string
+
+	// MaxQuantity is the largest Spec.Quantity that will be admitted. Zero disables the
+	// check, leaving only the CRD's kubebuilder Minimum=1 in effect.
+	MaxQuantity int
+
+	decoder admission.Decoder
+}
+
+var _ admission.Handler = &TacoOrderValidator{}
+
+// NewTacoOrderValidator builds a TacoOrderValidator backed by c, decoding requests with d.
+func NewTacoOrderValidator(c client.Client, d admission.Decoder, allowedVarieties []string, maxQuantity int) *TacoOrderValidator {
+	return &TacoOrderValidator{Client: c, AllowedVarieties: allowedVarieties, MaxQuantity: maxQuantity, decoder: d}
+}
+
+// Handle implements admission.Handler.
+func (v *TacoOrderValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var order tacoV1alpha1.TacoOrder
+	if err := v.decoder.Decode(req, &order); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Update {
+		var old tacoV1alpha1.TacoOrder
+		if err := v.decoder.DecodeRaw(req.OldObject, &old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if old.Status.ExternalOrderID != "" && !reflect.DeepEqual(old.Spec, order.Spec) {
+			return admission.Denied(fmt.Sprintf("spec is immutable once status.externalOrderID is set (order already placed as %q)", old.Status.ExternalOrderID))
+		}
+	}
+
+	if err := v.validateVariety(order.Spec.Variety); err != nil {
+		return admission.Denied(err.Error())
+	}
+	if err := v.validateQuantity(order.Spec.Quantity); err != nil {
+		return admission.Denied(err.Error())
+	}
+	if err := v.validateSecret(ctx, order.Namespace, order.Spec.PaymentSecretName, "cardNumber", "cardExpiry", "cardCvv"); err != nil {
+		return admission.Denied(err.Error())
+	}
+	if err := v.validateSecret(ctx, order.Namespace, order.Spec.AddressSecretName, "street", "city", "state", "zip"); err != nil {
+		return admission.Denied(err.Error())
+	}
+	if err := v.validateSecret(ctx, order.Namespace, order.Spec.ProviderCredentialsSecretName); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+// validateVariety rejects varieties outside AllowedVarieties. Variety is optional on
+// TacoOrderSpec, so an unset variety is always admitted regardless of the configured
+// allowlist.
+func (v *TacoOrderValidator) validateVariety(variety string) error {
+	if variety == "" || len(v.AllowedVarieties) == 0 {
+		return nil
+	}
+	for _, allowed := range v.AllowedVarieties {
+		if variety == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("variety %q is not in the allowed list %v", variety, v.AllowedVarieties)
+}
+
+// validateQuantity rejects a Quantity above MaxQuantity.
+func (v *TacoOrderValidator) validateQuantity(quantity int) error {
+	if v.MaxQuantity <= 0 || quantity <= v.MaxQuantity {
+		return nil
+	}
+	return fmt.Errorf("quantity %d exceeds the maximum of %d", quantity, v.MaxQuantity)
+}
+
+// validateSecret fetches the named Secret and rejects it if any of keys is missing or
+// empty.
+func (v *TacoOrderValidator) validateSecret(ctx context.Context, namespace, name string, keys ...string) error {
+	var secret corev1.Secret
+	if err := v.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		return fmt.Errorf("failed to fetch secret %q: %w", name, err)
+	}
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("secret %q is missing a non-empty %q key", name, key)
+		}
+	}
+	return nil
+}
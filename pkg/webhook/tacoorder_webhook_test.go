@@ -0,0 +1,59 @@
+package webhook
+
+import "testing"
+
+func TestValidateVariety(t *testing.T) {
+	v := &TacoOrderValidator{AllowedVarieties: []string{"carnitas", "al pastor"}}
+
+	cases := []struct {
+		name    string
+		variety string
+		wantErr bool
+	}{
+		{name: "allowed", variety: "carnitas", wantErr: false},
+		{name: "disallowed", variety: "durian", wantErr: true},
+		// Regression test for a57ed73: Variety is +optional on TacoOrderSpec, so an order
+		// that omits it must be admitted even though "" isn't in AllowedVarieties.
+		{name: "unset is always allowed", variety: "", wantErr: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.validateVariety(tc.variety)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validateVariety(%q) error = %v, wantErr %v", tc.variety, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateVariety_EmptyAllowlistAllowsAnything(t *testing.T) {
+	v := &TacoOrderValidator{}
+
+	if err := v.validateVariety("anything"); err != nil {
+		t.Fatalf("validateVariety with empty AllowedVarieties = %v, want nil", err)
+	}
+}
+
+func TestValidateQuantity(t *testing.T) {
+	cases := []struct {
+		name        string
+		maxQuantity int
+		quantity    int
+		wantErr     bool
+	}{
+		{name: "within bound", maxQuantity: 50, quantity: 50, wantErr: false},
+		{name: "over bound", maxQuantity: 50, quantity: 51, wantErr: true},
+		{name: "bound disabled", maxQuantity: 0, quantity: 1_000_000, wantErr: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &TacoOrderValidator{MaxQuantity: tc.maxQuantity}
+			err := v.validateQuantity(tc.quantity)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validateQuantity(%d) with MaxQuantity=%d error = %v, wantErr %v", tc.quantity, tc.maxQuantity, err, tc.wantErr)
+			}
+		})
+	}
+}